@@ -0,0 +1,84 @@
+// package admin wires up the face server's operational surface: Prometheus
+// metrics, health/readiness probes, and a small JSON diagnostics dump. It
+// is served on its own mux/address so it can be kept off the public API,
+// the same way the REST and gRPC surfaces are kept on theirs.
+package admin
+
+import (
+	"encoding/json"
+	"face/facestore"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// NewMux builds the admin mux for store.
+func NewMux(store facestore.Store) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", healthzHandler)
+	mux.HandleFunc("/readyz", readyzHandler(store))
+	mux.HandleFunc("/admin/faces", facesHandler(store))
+	return mux
+}
+
+// healthzHandler reports the process is up; it has no dependency to check,
+// unlike readyzHandler.
+func healthzHandler(w http.ResponseWriter, req *http.Request) {
+	w.Write([]byte("ok"))
+}
+
+// readyzHandler confirms the store actually answers a query before
+// reporting ready, so a load balancer doesn't send traffic to an instance
+// whose backend (e.g. etcd) isn't reachable yet.
+func readyzHandler(store facestore.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if _, err := store.GetAllFaces(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}
+}
+
+// stats summarizes the store's contents for /admin/faces, so an operator
+// can sanity-check what's in the store without dumping every face.
+type stats struct {
+	Total     int            `json:"total"`
+	PerTag    map[string]int `json:"per_tag"`
+	OldestDue *time.Time     `json:"oldest_due,omitempty"`
+	NewestDue *time.Time     `json:"newest_due,omitempty"`
+}
+
+func facesHandler(store facestore.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		faces, err := store.GetAllFaces()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		st := stats{Total: len(faces), PerTag: make(map[string]int)}
+		for _, f := range faces {
+			for _, tag := range f.Tags {
+				st.PerTag[tag]++
+			}
+			due := f.Due
+			if st.OldestDue == nil || due.Before(*st.OldestDue) {
+				st.OldestDue = &due
+			}
+			if st.NewestDue == nil || due.After(*st.NewestDue) {
+				st.NewestDue = &due
+			}
+		}
+
+		js, err := json.Marshal(st)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(js)
+	}
+}
@@ -0,0 +1,50 @@
+// facectl is a minimal client for FaceService. It doubles as a smoke test
+// for the server's gRPC listener: it creates a face and reads it back, so a
+// broken wiring shows up immediately. It is not a standard gRPC client --
+// FaceService runs a hand-written JSON codec (see proto/codec.go) instead
+// of real protobuf, so facectl is the only client that can talk to it;
+// grpcurl and other-language gRPC stubs cannot.
+package main
+
+import (
+	"context"
+	facepb "face/proto"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:9090", "address of the FaceService gRPC listener")
+	text := flag.String("text", "facectl smoke test", "text of the face to create")
+	flag.Parse()
+
+	conn, err := grpc.Dial(*addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(facepb.Codec())),
+	)
+	if err != nil {
+		log.Fatalf("dialing %s: %v", *addr, err)
+	}
+	defer conn.Close()
+
+	client := facepb.NewFaceServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	created, err := client.CreateFace(ctx, &facepb.CreateFaceRequest{Text: *text, Due: time.Now()})
+	if err != nil {
+		log.Fatalf("CreateFace: %v", err)
+	}
+
+	got, err := client.GetFace(ctx, &facepb.GetFaceRequest{Id: created.Id})
+	if err != nil {
+		log.Fatalf("GetFace(%d): %v", created.Id, err)
+	}
+
+	fmt.Printf("created and fetched face %d: %q\n", got.Id, got.Text)
+}
@@ -0,0 +1,16 @@
+package facestore
+
+import "errors"
+
+// ErrNotFound is returned by Store methods when the requested face id does
+// not exist.
+var ErrNotFound = errors.New("face not found")
+
+// ErrCompacted is returned by Watch when events right after fromRev are no
+// longer in the store's bounded event history, so the caller must refetch
+// state instead of resuming the stream.
+var ErrCompacted = errors.New("revision compacted")
+
+// ErrPreconditionFailed is returned by UpdateFace when patch.IfMatchVersion
+// is set but doesn't match the face's current Version.
+var ErrPreconditionFailed = errors.New("precondition failed: face has been modified")
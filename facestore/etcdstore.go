@@ -0,0 +1,411 @@
+package facestore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// EtcdStore is a Store backed by etcd v3. Each face is stored as a JSON
+// value under /faces/<id>; a single counter key hands out ids, and every
+// mutation also maintains secondary index keys under /faces/by-tag/<tag>/<id>
+// and /faces/by-due/<yyyy-mm-dd>/<id> so GetFacesByTag/GetFacesByDueDate can
+// do a prefix scan instead of reading every face.
+type EtcdStore struct {
+	cli    *clientv3.Client
+	prefix string
+}
+
+const (
+	counterKey = "/faces/counter"
+	faceKeyFmt = "/faces/%d"
+
+	// resetKey is put (never deleted) by DeleteAllFaces so Watch can emit a
+	// single EventReset, as MemStore and SQLStore do, instead of the burst
+	// of per-key EventDeletes etcd's native watch reports for the bulk
+	// delete.
+	resetKey     = "/faces/reset"
+	tagIndexFmt  = "/faces/by-tag/%s/%d"
+	dueIndexFmt  = "/faces/by-due/%s/%d"
+	requestTimeo = 5 * time.Second
+)
+
+// NewEtcdStore dials the given etcd endpoints and returns a Store backed by
+// them.
+func NewEtcdStore(endpoints []string) (*EtcdStore, error) {
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: requestTimeo,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dialing etcd: %w", err)
+	}
+	return &EtcdStore{cli: cli}, nil
+}
+
+func (s *EtcdStore) Close() error {
+	return s.cli.Close()
+}
+
+func dueIndexKey(due time.Time, id int) string {
+	return fmt.Sprintf(dueIndexFmt, due.Format("2006-01-02"), id)
+}
+
+// nextId atomically increments counterKey and returns its new value as the
+// id for a newly created face.
+func (s *EtcdStore) nextId(ctx context.Context) (int, error) {
+	for {
+		resp, err := s.cli.Get(ctx, counterKey)
+		if err != nil {
+			return 0, err
+		}
+
+		var cur int64
+		var modRev int64
+		if len(resp.Kvs) > 0 {
+			cur, err = strconv.ParseInt(string(resp.Kvs[0].Value), 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			modRev = resp.Kvs[0].ModRevision
+		}
+		next := cur + 1
+
+		txn := s.cli.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(counterKey), "=", modRev)).
+			Then(clientv3.OpPut(counterKey, strconv.FormatInt(next, 10)))
+		txnResp, err := txn.Commit()
+		if err != nil {
+			return 0, err
+		}
+		if txnResp.Succeeded {
+			return int(next), nil
+		}
+		// Someone else updated the counter concurrently; retry.
+	}
+}
+
+func (s *EtcdStore) CreateFace(text string, tags []string, due time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeo)
+	defer cancel()
+
+	id, err := s.nextId(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("allocating face id: %w", err)
+	}
+
+	copiedTags := make([]string, len(tags))
+	copy(copiedTags, tags)
+	face := Face{Id: id, Text: text, Tags: copiedTags, Due: due, Version: 1}
+	val, err := json.Marshal(face)
+	if err != nil {
+		return 0, err
+	}
+
+	ops := []clientv3.Op{clientv3.OpPut(fmt.Sprintf(faceKeyFmt, id), string(val))}
+	for _, tag := range face.Tags {
+		ops = append(ops, clientv3.OpPut(fmt.Sprintf(tagIndexFmt, tag, id), ""))
+	}
+	ops = append(ops, clientv3.OpPut(dueIndexKey(due, id), ""))
+
+	if _, err := s.cli.Txn(ctx).Then(ops...).Commit(); err != nil {
+		return 0, fmt.Errorf("writing face id=%d: %w", id, err)
+	}
+	return id, nil
+}
+
+func (s *EtcdStore) GetFace(id int) (Face, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeo)
+	defer cancel()
+
+	resp, err := s.cli.Get(ctx, fmt.Sprintf(faceKeyFmt, id))
+	if err != nil {
+		return Face{}, err
+	}
+	if len(resp.Kvs) == 0 {
+		return Face{}, fmt.Errorf("face with id=%d: %w", id, ErrNotFound)
+	}
+
+	var f Face
+	if err := json.Unmarshal(resp.Kvs[0].Value, &f); err != nil {
+		return Face{}, err
+	}
+	return f, nil
+}
+
+// UpdateFace rewrites the face value and, since tags/due may have changed,
+// drops the old index entries and writes fresh ones in the same
+// transaction.
+func (s *EtcdStore) UpdateFace(id int, patch FacePatch) (Face, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeo)
+	defer cancel()
+
+	faceKey := fmt.Sprintf(faceKeyFmt, id)
+	getResp, err := s.cli.Get(ctx, faceKey)
+	if err != nil {
+		return Face{}, err
+	}
+	if len(getResp.Kvs) == 0 {
+		return Face{}, fmt.Errorf("face with id=%d: %w", id, ErrNotFound)
+	}
+	var old Face
+	if err := json.Unmarshal(getResp.Kvs[0].Value, &old); err != nil {
+		return Face{}, err
+	}
+	if patch.IfMatchVersion != nil && *patch.IfMatchVersion != old.Version {
+		return Face{}, fmt.Errorf("face with id=%d at version %d: %w", id, old.Version, ErrPreconditionFailed)
+	}
+
+	updated := old
+	if patch.Text != nil {
+		updated.Text = *patch.Text
+	}
+	if patch.Tags != nil {
+		updated.Tags = *patch.Tags
+		if updated.Tags == nil {
+			updated.Tags = []string{}
+		}
+	}
+	if patch.Due != nil {
+		updated.Due = *patch.Due
+	}
+	updated.Version++
+
+	val, err := json.Marshal(updated)
+	if err != nil {
+		return Face{}, err
+	}
+
+	// Index keys the update keeps are only re-put, not also deleted: etcd
+	// rejects a transaction that deletes and puts the same key.
+	newIdx := map[string]bool{dueIndexKey(updated.Due, id): true}
+	for _, tag := range updated.Tags {
+		newIdx[fmt.Sprintf(tagIndexFmt, tag, id)] = true
+	}
+
+	var ops []clientv3.Op
+	for _, tag := range old.Tags {
+		if k := fmt.Sprintf(tagIndexFmt, tag, id); !newIdx[k] {
+			ops = append(ops, clientv3.OpDelete(k))
+		}
+	}
+	if k := dueIndexKey(old.Due, id); !newIdx[k] {
+		ops = append(ops, clientv3.OpDelete(k))
+	}
+
+	ops = append(ops, clientv3.OpPut(faceKey, string(val)))
+	for k := range newIdx {
+		ops = append(ops, clientv3.OpPut(k, ""))
+	}
+
+	// The ModRevision compare makes this an optimistic-concurrency check:
+	// if another writer touched the face between our Get and this Commit,
+	// the transaction's Then branch doesn't run and we report the same
+	// ErrPreconditionFailed a stale IfMatchVersion would have.
+	txnResp, err := s.cli.Txn(ctx).
+		If(clientv3.Compare(clientv3.ModRevision(faceKey), "=", getResp.Kvs[0].ModRevision)).
+		Then(ops...).
+		Commit()
+	if err != nil {
+		return Face{}, fmt.Errorf("updating face id=%d: %w", id, err)
+	}
+	if !txnResp.Succeeded {
+		return Face{}, fmt.Errorf("face with id=%d: %w", id, ErrPreconditionFailed)
+	}
+	return updated, nil
+}
+
+func (s *EtcdStore) DeleteFace(id int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeo)
+	defer cancel()
+
+	f, err := s.GetFace(id)
+	if err != nil {
+		return err
+	}
+
+	ops := []clientv3.Op{clientv3.OpDelete(fmt.Sprintf(faceKeyFmt, id))}
+	for _, tag := range f.Tags {
+		ops = append(ops, clientv3.OpDelete(fmt.Sprintf(tagIndexFmt, tag, id)))
+	}
+	ops = append(ops, clientv3.OpDelete(dueIndexKey(f.Due, id)))
+
+	_, err = s.cli.Txn(ctx).Then(ops...).Commit()
+	return err
+}
+
+// DeleteAllFaces deletes every face record and index entry, but leaves
+// counterKey alone -- a plain prefix delete on "/faces/" would also wipe
+// the counter, so the next CreateFace would restart ids from 1 and
+// silently reuse ids from before the wipe (unlike MemStore's monotonic
+// nextId or SQLStore's AUTOINCREMENT). "/faces/0" through "/faces/:"
+// (":" is the ASCII byte right after "9") covers exactly the numeric
+// /faces/<id> keys, without reaching into "/faces/by-tag/", "/faces/by-due/"
+// or "/faces/counter", all of which sort after "/faces/9...".
+func (s *EtcdStore) DeleteAllFaces() error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeo)
+	defer cancel()
+
+	_, err := s.cli.Txn(ctx).Then(
+		clientv3.OpDelete("/faces/0", clientv3.WithRange("/faces/:")),
+		clientv3.OpDelete("/faces/by-tag/", clientv3.WithPrefix()),
+		clientv3.OpDelete("/faces/by-due/", clientv3.WithPrefix()),
+		clientv3.OpPut(resetKey, ""),
+	).Commit()
+	return err
+}
+
+func (s *EtcdStore) GetAllFaces() ([]Face, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeo)
+	defer cancel()
+
+	resp, err := s.cli.Get(ctx, "/faces/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	var faces []Face
+	for _, kv := range resp.Kvs {
+		// Index keys live under /faces/by-tag/ and /faces/by-due/, the
+		// counter lives at /faces/counter, and resetKey holds an empty
+		// value; only /faces/<id> holds a Face, so anything else just
+		// fails to unmarshal and is skipped.
+		var f Face
+		if err := json.Unmarshal(kv.Value, &f); err != nil {
+			continue
+		}
+		faces = append(faces, f)
+	}
+	return faces, nil
+}
+
+func (s *EtcdStore) getByPrefix(prefix string) ([]Face, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeo)
+	defer cancel()
+
+	resp, err := s.cli.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, err
+	}
+
+	var faces []Face
+	for _, kv := range resp.Kvs {
+		key := string(kv.Key)
+		idx := lastSlash(key)
+		id, err := strconv.Atoi(key[idx+1:])
+		if err != nil {
+			continue
+		}
+		f, err := s.GetFace(id)
+		if err != nil {
+			continue
+		}
+		faces = append(faces, f)
+	}
+	return faces, nil
+}
+
+func lastSlash(s string) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] == '/' {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *EtcdStore) GetFacesByTag(tag string) ([]Face, error) {
+	return s.getByPrefix(fmt.Sprintf("/faces/by-tag/%s/", tag))
+}
+
+func (s *EtcdStore) GetFacesByDueDate(year int, month time.Month, day int) ([]Face, error) {
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+	return s.getByPrefix(fmt.Sprintf("/faces/by-due/%s/", date))
+}
+
+// Watch proxies etcd's native watch on the /faces/ prefix, decoding each
+// key event into a Store Event. fromRev is an etcd revision; since etcd's
+// WithRev is inclusive, the watch starts at fromRev+1. A write to resetKey
+// (see DeleteAllFaces) is surfaced as a single EventReset rather than the
+// burst of per-key EventDeletes etcd's watch otherwise produces for a bulk
+// delete.
+func (s *EtcdStore) Watch(ctx context.Context, fromRev int64) (<-chan Event, error) {
+	opts := []clientv3.OpOption{clientv3.WithPrefix()}
+	if fromRev > 0 {
+		opts = append(opts, clientv3.WithRev(fromRev+1))
+	}
+	wch := s.cli.Watch(ctx, "/faces/", opts...)
+
+	out := make(chan Event, 16)
+	go func() {
+		defer close(out)
+		for resp := range wch {
+			if resp.Err() != nil {
+				return
+			}
+			// DeleteAllFaces' per-face deletes share the revision of its
+			// resetKey write; they are folded into that one EventReset.
+			resetRevs := make(map[int64]bool)
+			for _, ev := range resp.Events {
+				if string(ev.Kv.Key) == resetKey {
+					resetRevs[ev.Kv.ModRevision] = true
+				}
+			}
+			for _, ev := range resp.Events {
+				key := string(ev.Kv.Key)
+				if key == resetKey {
+					select {
+					case out <- Event{Rev: ev.Kv.ModRevision, Type: EventReset, Face: Face{}}:
+					case <-ctx.Done():
+						return
+					}
+					continue
+				}
+				// Only primary /faces/<id> keys are surfaced; index key
+				// churn is an implementation detail.
+				if !isFaceKey(key) {
+					continue
+				}
+				if ev.Type == clientv3.EventTypeDelete && resetRevs[ev.Kv.ModRevision] {
+					continue
+				}
+				var f Face
+				typ := EventUpdate
+				switch {
+				case ev.Type == clientv3.EventTypeDelete:
+					typ = EventDelete
+				case ev.IsCreate():
+					typ = EventCreate
+				}
+				if ev.Type != clientv3.EventTypeDelete {
+					_ = json.Unmarshal(ev.Kv.Value, &f)
+				} else {
+					// A delete event carries no value; take the id from the key.
+					f.Id, _ = strconv.Atoi(key[len("/faces/"):])
+				}
+				select {
+				case out <- Event{Rev: ev.Kv.ModRevision, Type: typ, Face: f}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func isFaceKey(key string) bool {
+	if len(key) <= len("/faces/") || key[len("/faces/")] == 'b' /* by-tag, by-due */ {
+		return false
+	}
+	if key == counterKey || key == resetKey {
+		return false
+	}
+	return true
+}
+
+var _ Store = (*EtcdStore)(nil)
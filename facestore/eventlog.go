@@ -0,0 +1,129 @@
+package facestore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// eventLog is a revisioned, bounded-history pub/sub used by MemStore to back
+// Watch. It keeps the last `capacity` events so a subscriber that names a
+// fromRev still within that window can replay the events it missed before
+// joining the live fan-out; a subscriber asking for an older revision gets
+// ErrCompacted instead of being silently resumed from the wrong place.
+type eventLog struct {
+	mu       sync.Mutex
+	capacity int
+	rev      int64
+	oldest   int64 // rev of buf[0], once buf is non-empty
+	buf      []Event
+	subs     map[chan Event]struct{}
+}
+
+func newEventLog(capacity int) *eventLog {
+	return &eventLog{
+		capacity: capacity,
+		buf:      make([]Event, 0, capacity),
+		subs:     make(map[chan Event]struct{}),
+	}
+}
+
+// publish bumps the revision, records the event in the ring buffer, and
+// fans it out to subscribers without blocking: a subscriber whose channel
+// is full is disconnected rather than stalling the writer, since its only
+// durability guarantee is the ring buffer, not an unbounded queue. A
+// disconnected subscriber is sent a final EventLagged first (dropping its
+// oldest buffered event to make room if needed) so it can tell "fell
+// behind" apart from a clean, caught-up close.
+func (l *eventLog) publish(typ EventType, face Face) Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rev++
+	ev := Event{Rev: l.rev, Type: typ, Face: face}
+
+	if len(l.buf) == l.capacity {
+		l.buf = l.buf[1:]
+		l.oldest = l.buf[0].Rev
+	} else if len(l.buf) == 0 {
+		l.oldest = ev.Rev
+	}
+	l.buf = append(l.buf, ev)
+
+	for ch := range l.subs {
+		select {
+		case ch <- ev:
+		default:
+			delete(l.subs, ch)
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- Event{Rev: ev.Rev, Type: EventLagged}:
+			default:
+			}
+			close(ch)
+		}
+	}
+	return ev
+}
+
+// subscribe returns a channel that first replays any buffered events after
+// fromRev, then stays open for live events, until ctx is done. fromRev == 0
+// means "only events from now on". If the event right after fromRev is no
+// longer retained (fromRev < oldest-1), ErrCompacted is returned so the
+// caller can refetch full state instead of silently missing history.
+func (l *eventLog) subscribe(ctx context.Context, fromRev int64) (<-chan Event, error) {
+	l.mu.Lock()
+
+	if fromRev > 0 && len(l.buf) > 0 && fromRev < l.oldest-1 {
+		l.mu.Unlock()
+		return nil, fmt.Errorf("requested revision %d: %w", fromRev, ErrCompacted)
+	}
+
+	var backlog []Event
+	if fromRev > 0 {
+		for _, ev := range l.buf {
+			if ev.Rev > fromRev {
+				backlog = append(backlog, ev)
+			}
+		}
+	}
+
+	ch := make(chan Event, l.capacity)
+	l.subs[ch] = struct{}{}
+	l.mu.Unlock()
+
+	out := make(chan Event, l.capacity)
+	go func() {
+		defer close(out)
+		for _, ev := range backlog {
+			select {
+			case out <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+		for {
+			select {
+			case ev, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case out <- ev:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				l.mu.Lock()
+				delete(l.subs, ch)
+				l.mu.Unlock()
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
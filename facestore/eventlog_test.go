@@ -0,0 +1,41 @@
+package facestore
+
+import "testing"
+
+// TestEventLogPublishLaggedSubscriber exercises eventLog.publish's
+// disconnect path directly against a raw subscriber channel (rather than
+// one obtained through subscribe, whose forwarding goroutine would race
+// to drain it and might prevent the buffer from ever filling).
+func TestEventLogPublishLaggedSubscriber(t *testing.T) {
+	l := newEventLog(2)
+
+	ch := make(chan Event, 2)
+	l.mu.Lock()
+	l.subs[ch] = struct{}{}
+	l.mu.Unlock()
+
+	// The first two publishes fill ch's two-slot buffer; the third finds
+	// it full and should disconnect it with a final EventLagged rather
+	// than silently closing it, dropping the oldest buffered event (id=1)
+	// to make room for the sentinel.
+	l.publish(EventCreate, Face{Id: 1})
+	l.publish(EventCreate, Face{Id: 2})
+	l.publish(EventCreate, Face{Id: 3})
+
+	first := <-ch
+	if first.Type != EventCreate || first.Face.Id != 2 {
+		t.Fatalf("first event = %+v, want EventCreate for id=2", first)
+	}
+
+	second, ok := <-ch
+	if !ok {
+		t.Fatal("channel closed without a final EventLagged")
+	}
+	if second.Type != EventLagged {
+		t.Fatalf("second event = %+v, want EventLagged", second)
+	}
+
+	if _, ok := <-ch; ok {
+		t.Fatal("expected the channel to be closed after EventLagged")
+	}
+}
@@ -0,0 +1,208 @@
+// package facestore provides a pluggable "data store" for faces, identified
+// by numeric IDs. MemStore is the original in-memory implementation; see
+// sqlstore.go and etcdstore.go for persistent backends.
+//
+// Eli Bendersky [https://eli.thegreenplace.net]
+// This code is in the public domain.
+package facestore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Face struct {
+	Id   int       `json:"id"`
+	Text string    `json:"text"`
+	Tags []string  `json:"tags"`
+	Due  time.Time `json:"due"`
+
+	// Version is incremented on every mutation and surfaced as the ETag,
+	// so If-Match can detect a concurrent update (see UpdateFace).
+	Version int `json:"version"`
+}
+
+// MemStore is a simple in-memory Store; MemStore methods are safe to call
+// concurrently.
+type MemStore struct {
+	mu sync.Mutex
+
+	faces  map[int]Face
+	nextId int
+
+	events *eventLog
+}
+
+// eventLogCapacity bounds how many past events a watcher can replay after
+// reconnecting; older revisions are compacted away.
+const eventLogCapacity = 1024
+
+// New returns a new, empty MemStore. Kept as the package-level constructor
+// since MemStore is the default backend.
+func New() *MemStore {
+	return NewMemStore()
+}
+
+func NewMemStore() *MemStore {
+	return &MemStore{
+		faces:  make(map[int]Face),
+		events: newEventLog(eventLogCapacity),
+	}
+}
+
+// publish records a mutation in the event log so Watch subscribers (and
+// reconnecting ones within the ring buffer's window) see it. Must be called
+// with mu held, so events are published in the same order faces mutate.
+func (ts *MemStore) publish(typ EventType, face Face) {
+	ts.events.publish(typ, face)
+}
+
+// CreateFace creates a new face in the store.
+func (ts *MemStore) CreateFace(text string, tags []string, due time.Time) (int, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	face := Face{
+		Id:      ts.nextId,
+		Text:    text,
+		Due:     due,
+		Version: 1,
+	}
+	face.Tags = make([]string, len(tags))
+	copy(face.Tags, tags)
+
+	ts.faces[ts.nextId] = face
+	ts.nextId++
+	ts.publish(EventCreate, face)
+	return face.Id, nil
+}
+
+// GetFace retrieves a face from the store, by id. If no such id exists, an
+// error is returned.
+func (ts *MemStore) GetFace(id int) (Face, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	f, ok := ts.faces[id]
+	if !ok {
+		return Face{}, fmt.Errorf("face with id=%d: %w", id, ErrNotFound)
+	}
+	return f, nil
+}
+
+// UpdateFace applies patch to the face with the given id, preserving its id.
+func (ts *MemStore) UpdateFace(id int, patch FacePatch) (Face, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	f, ok := ts.faces[id]
+	if !ok {
+		return Face{}, fmt.Errorf("face with id=%d: %w", id, ErrNotFound)
+	}
+	if patch.IfMatchVersion != nil && *patch.IfMatchVersion != f.Version {
+		return Face{}, fmt.Errorf("face with id=%d at version %d: %w", id, f.Version, ErrPreconditionFailed)
+	}
+
+	if patch.Text != nil {
+		f.Text = *patch.Text
+	}
+	if patch.Tags != nil {
+		f.Tags = make([]string, len(*patch.Tags))
+		copy(f.Tags, *patch.Tags)
+	}
+	if patch.Due != nil {
+		f.Due = *patch.Due
+	}
+	f.Version++
+
+	ts.faces[id] = f
+	ts.publish(EventUpdate, f)
+	return f, nil
+}
+
+// DeleteFace deletes the face with the given id. If no such id exists, an
+// error is returned.
+func (ts *MemStore) DeleteFace(id int) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	f, ok := ts.faces[id]
+	if !ok {
+		return fmt.Errorf("face with id=%d: %w", id, ErrNotFound)
+	}
+
+	delete(ts.faces, id)
+	ts.publish(EventDelete, f)
+	return nil
+}
+
+// DeleteAllFaces deletes all faces in the store.
+func (ts *MemStore) DeleteAllFaces() error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.faces = make(map[int]Face)
+	ts.publish(EventReset, Face{})
+	return nil
+}
+
+// GetAllFaces returns all the faces in the store, in arbitrary order.
+func (ts *MemStore) GetAllFaces() ([]Face, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	allFaces := make([]Face, 0, len(ts.faces))
+	for _, face := range ts.faces {
+		allFaces = append(allFaces, face)
+	}
+	return allFaces, nil
+}
+
+// GetFacesByTag returns all the faces that have the given tag, in arbitrary
+// order.
+func (ts *MemStore) GetFacesByTag(tag string) ([]Face, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	var faces []Face
+
+faceloop:
+	for _, face := range ts.faces {
+		for _, faceTag := range face.Tags {
+			if faceTag == tag {
+				faces = append(faces, face)
+				continue faceloop
+			}
+		}
+	}
+	return faces, nil
+}
+
+// GetFacesByDueDate returns all the faces that have the given due date, in
+// arbitrary order.
+func (ts *MemStore) GetFacesByDueDate(year int, month time.Month, day int) ([]Face, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	var faces []Face
+
+	for _, face := range ts.faces {
+		y, m, d := face.Due.Date()
+		if y == year && m == month && d == day {
+			faces = append(faces, face)
+		}
+	}
+
+	return faces, nil
+}
+
+// Watch streams Events for every mutation after fromRev, replaying any
+// still-retained backlog before switching to live events. See store.go for
+// the contract and eventlog.go for the ring buffer/compaction behavior.
+func (ts *MemStore) Watch(ctx context.Context, fromRev int64) (<-chan Event, error) {
+	return ts.events.subscribe(ctx, fromRev)
+}
+
+var _ Store = (*MemStore)(nil)
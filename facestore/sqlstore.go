@@ -0,0 +1,249 @@
+package facestore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLStore is a Store backed by a SQLite database through database/sql.
+// NewSQLStore always opens the "sqlite3" driver, so its dsn is a SQLite
+// data source name; this is the backend the "sqlite" flag selects.
+type SQLStore struct {
+	db *sql.DB
+
+	events *eventLog
+}
+
+const sqlSchema = `
+CREATE TABLE IF NOT EXISTS faces (
+	id      INTEGER PRIMARY KEY AUTOINCREMENT,
+	text    TEXT NOT NULL,
+	tags    TEXT NOT NULL, -- JSON-encoded []string
+	due     DATETIME NOT NULL,
+	version INTEGER NOT NULL DEFAULT 1
+);
+`
+
+// NewSQLStore opens (creating if necessary) a SQLite database at dsn and
+// returns a Store backed by it.
+func NewSQLStore(dsn string) (*SQLStore, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite store: %w", err)
+	}
+	if _, err := db.Exec(sqlSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating sqlite schema: %w", err)
+	}
+	return &SQLStore{db: db, events: newEventLog(eventLogCapacity)}, nil
+}
+
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
+
+func encodeTags(tags []string) (string, error) {
+	b, err := json.Marshal(tags)
+	return string(b), err
+}
+
+func decodeTags(raw string) ([]string, error) {
+	var tags []string
+	if err := json.Unmarshal([]byte(raw), &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func scanFace(row interface{ Scan(...interface{}) error }) (Face, error) {
+	var f Face
+	var tagsJSON string
+	if err := row.Scan(&f.Id, &f.Text, &tagsJSON, &f.Due, &f.Version); err != nil {
+		return Face{}, err
+	}
+	tags, err := decodeTags(tagsJSON)
+	if err != nil {
+		return Face{}, err
+	}
+	f.Tags = tags
+	return f, nil
+}
+
+func (s *SQLStore) CreateFace(text string, tags []string, due time.Time) (int, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+	tagsJSON, err := encodeTags(tags)
+	if err != nil {
+		return 0, err
+	}
+	res, err := s.db.Exec(`INSERT INTO faces (text, tags, due, version) VALUES (?, ?, ?, 1)`, text, tagsJSON, due)
+	if err != nil {
+		return 0, fmt.Errorf("inserting face: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	s.events.publish(EventCreate, Face{Id: int(id), Text: text, Tags: tags, Due: due, Version: 1})
+	return int(id), nil
+}
+
+func (s *SQLStore) GetFace(id int) (Face, error) {
+	row := s.db.QueryRow(`SELECT id, text, tags, due, version FROM faces WHERE id = ?`, id)
+	f, err := scanFace(row)
+	if err == sql.ErrNoRows {
+		return Face{}, fmt.Errorf("face with id=%d: %w", id, ErrNotFound)
+	} else if err != nil {
+		return Face{}, err
+	}
+	return f, nil
+}
+
+func (s *SQLStore) UpdateFace(id int, patch FacePatch) (Face, error) {
+	f, err := s.GetFace(id)
+	if err != nil {
+		return Face{}, err
+	}
+	if patch.IfMatchVersion != nil && *patch.IfMatchVersion != f.Version {
+		return Face{}, fmt.Errorf("face with id=%d at version %d: %w", id, f.Version, ErrPreconditionFailed)
+	}
+
+	if patch.Text != nil {
+		f.Text = *patch.Text
+	}
+	if patch.Tags != nil {
+		f.Tags = *patch.Tags
+		if f.Tags == nil {
+			f.Tags = []string{}
+		}
+	}
+	if patch.Due != nil {
+		f.Due = *patch.Due
+	}
+	f.Version++
+
+	tagsJSON, err := encodeTags(f.Tags)
+	if err != nil {
+		return Face{}, err
+	}
+	// The version predicate makes this an optimistic-concurrency check: if
+	// another update won the race since GetFace above, this affects zero
+	// rows and we report the same ErrPreconditionFailed as the upfront
+	// check would have.
+	res, err := s.db.Exec(`UPDATE faces SET text = ?, tags = ?, due = ?, version = ? WHERE id = ? AND version = ?`,
+		f.Text, tagsJSON, f.Due, f.Version, id, f.Version-1)
+	if err != nil {
+		return Face{}, fmt.Errorf("updating face id=%d: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return Face{}, err
+	} else if n == 0 {
+		return Face{}, fmt.Errorf("face with id=%d: %w", id, ErrPreconditionFailed)
+	}
+	s.events.publish(EventUpdate, f)
+	return f, nil
+}
+
+func (s *SQLStore) DeleteFace(id int) error {
+	f, err := s.GetFace(id)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.Exec(`DELETE FROM faces WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("deleting face id=%d: %w", id, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("face with id=%d: %w", id, ErrNotFound)
+	}
+	s.events.publish(EventDelete, f)
+	return nil
+}
+
+func (s *SQLStore) DeleteAllFaces() error {
+	if _, err := s.db.Exec(`DELETE FROM faces`); err != nil {
+		return err
+	}
+	s.events.publish(EventReset, Face{})
+	return nil
+}
+
+func (s *SQLStore) GetAllFaces() ([]Face, error) {
+	rows, err := s.db.Query(`SELECT id, text, tags, due, version FROM faces`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var faces []Face
+	for rows.Next() {
+		f, err := scanFace(rows)
+		if err != nil {
+			return nil, err
+		}
+		faces = append(faces, f)
+	}
+	return faces, rows.Err()
+}
+
+// GetFacesByTag scans all rows and filters in Go, since tags are stored as a
+// single JSON column rather than a normalized join table.
+func (s *SQLStore) GetFacesByTag(tag string) ([]Face, error) {
+	all, err := s.GetAllFaces()
+	if err != nil {
+		return nil, err
+	}
+	var faces []Face
+	for _, f := range all {
+		for _, t := range f.Tags {
+			if t == tag {
+				faces = append(faces, f)
+				break
+			}
+		}
+	}
+	return faces, nil
+}
+
+func (s *SQLStore) GetFacesByDueDate(year int, month time.Month, day int) ([]Face, error) {
+	start := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	rows, err := s.db.Query(`SELECT id, text, tags, due, version FROM faces WHERE due >= ? AND due < ?`, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var faces []Face
+	for rows.Next() {
+		f, err := scanFace(rows)
+		if err != nil {
+			return nil, err
+		}
+		faces = append(faces, f)
+	}
+	return faces, rows.Err()
+}
+
+// Watch streams Events for every mutation this SQLStore makes after fromRev.
+// It is not backed by SQLite's write-ahead log, so it has no way to learn
+// about mutations from other processes or other *SQLStore handles on the
+// same database file -- only ones made through this Store value. Reaching
+// for the etcd backend is the supported way to watch across processes (see
+// EtcdStore.Watch).
+func (s *SQLStore) Watch(ctx context.Context, fromRev int64) (<-chan Event, error) {
+	return s.events.subscribe(ctx, fromRev)
+}
+
+var _ Store = (*SQLStore)(nil)
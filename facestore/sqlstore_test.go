@@ -0,0 +1,113 @@
+package facestore
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestSQLStore(t *testing.T) *SQLStore {
+	t.Helper()
+	s, err := NewSQLStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLStore: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestSQLStoreUpdateFaceIfMatchVersion(t *testing.T) {
+	s := newTestSQLStore(t)
+
+	id, err := s.CreateFace("hello", nil, time.Now())
+	if err != nil {
+		t.Fatalf("CreateFace: %v", err)
+	}
+
+	staleVersion := 999
+	_, err = s.UpdateFace(id, FacePatch{IfMatchVersion: &staleVersion})
+	if err == nil {
+		t.Fatal("expected ErrPreconditionFailed for a stale version, got nil")
+	}
+
+	text := "updated"
+	currentVersion := 1
+	updated, err := s.UpdateFace(id, FacePatch{Text: &text, IfMatchVersion: &currentVersion})
+	if err != nil {
+		t.Fatalf("UpdateFace with a matching version: %v", err)
+	}
+	if updated.Text != text || updated.Version != 2 {
+		t.Fatalf("updated = %+v, want Text=%q Version=2", updated, text)
+	}
+}
+
+func TestSQLStoreCreateFaceNilTags(t *testing.T) {
+	s := newTestSQLStore(t)
+
+	id, err := s.CreateFace("hello", nil, time.Now())
+	if err != nil {
+		t.Fatalf("CreateFace: %v", err)
+	}
+	f, err := s.GetFace(id)
+	if err != nil {
+		t.Fatalf("GetFace: %v", err)
+	}
+	if f.Tags == nil {
+		t.Fatal("Tags = nil, want a non-nil empty slice")
+	}
+	if len(f.Tags) != 0 {
+		t.Fatalf("Tags = %v, want empty", f.Tags)
+	}
+}
+
+func TestSQLStoreUpdateFaceNilTags(t *testing.T) {
+	s := newTestSQLStore(t)
+
+	id, err := s.CreateFace("hello", []string{"a"}, time.Now())
+	if err != nil {
+		t.Fatalf("CreateFace: %v", err)
+	}
+
+	var nilTags []string
+	updated, err := s.UpdateFace(id, FacePatch{Tags: &nilTags})
+	if err != nil {
+		t.Fatalf("UpdateFace: %v", err)
+	}
+	if updated.Tags == nil {
+		t.Fatal("UpdateFace result Tags = nil, want a non-nil empty slice")
+	}
+
+	f, err := s.GetFace(id)
+	if err != nil {
+		t.Fatalf("GetFace: %v", err)
+	}
+	if f.Tags == nil {
+		t.Fatal("Tags = nil, want a non-nil empty slice")
+	}
+}
+
+func TestSQLStoreWatch(t *testing.T) {
+	s := newTestSQLStore(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	ch, err := s.Watch(ctx, 0)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	id, err := s.CreateFace("hello", nil, time.Now())
+	if err != nil {
+		t.Fatalf("CreateFace: %v", err)
+	}
+
+	select {
+	case ev := <-ch:
+		if ev.Type != EventCreate || ev.Face.Id != id {
+			t.Fatalf("event = %+v, want an EventCreate for id=%d", ev, id)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the CreateFace event")
+	}
+}
@@ -0,0 +1,123 @@
+package facestore
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// EventType identifies the kind of mutation that produced an Event.
+type EventType int
+
+const (
+	EventCreate EventType = iota
+	EventUpdate
+	EventDelete
+
+	// EventReset marks a DeleteAllFaces call. Its Event.Face is always the
+	// zero value and must not be read as "face id 0 was deleted" -- that's
+	// exactly the ambiguity a bare EventDelete with a zero-value Face would
+	// create, since id 0 is a legitimate face id in MemStore, whose counter
+	// starts at 0.
+	EventReset
+
+	// EventLagged is the final event a subscriber channel receives before
+	// it's closed for falling too far behind (its buffer filled faster than
+	// it was drained). It means the subscriber missed events, not that
+	// there are no more -- a /watch/ consumer that sees one should treat
+	// its stream as broken (eventlog.go's ring buffer can't replay what it
+	// never retained) and report that to its own caller instead of quietly
+	// resuming from the same since/Last-Event-ID.
+	EventLagged
+)
+
+// Event describes a single mutation of the store, tagged with the revision
+// it produced. Revisions are monotonically increasing per Store and are
+// used by Watch to resume a stream after a given point.
+type Event struct {
+	Rev  int64     `json:"rev"`
+	Type EventType `json:"type"`
+	Face Face      `json:"face"`
+}
+
+// String renders an EventType the way it appears in JSON and log output.
+func (t EventType) String() string {
+	switch t {
+	case EventCreate:
+		return "create"
+	case EventUpdate:
+		return "update"
+	case EventDelete:
+		return "delete"
+	case EventReset:
+		return "reset"
+	case EventLagged:
+		return "lagged"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON renders an EventType as its string name rather than a bare
+// int, so /watch/ consumers don't have to hardcode the enum order.
+func (t EventType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// Store is the interface implemented by every FaceStore backend: the
+// in-memory MemStore, the SQLite-backed SQLStore, and the etcd-backed
+// EtcdStore. faceServer depends only on Store, so new backends can be added
+// without touching the HTTP layer.
+type Store interface {
+	// CreateFace creates a new face in the store and returns its id.
+	CreateFace(text string, tags []string, due time.Time) (int, error)
+
+	// GetFace retrieves a face from the store, by id. If no such id exists,
+	// an error is returned.
+	GetFace(id int) (Face, error)
+
+	// UpdateFace applies patch to the face with the given id, preserving its
+	// id, and returns the updated face. If no such id exists, an error is
+	// returned.
+	UpdateFace(id int, patch FacePatch) (Face, error)
+
+	// DeleteFace deletes the face with the given id. If no such id exists,
+	// an error is returned.
+	DeleteFace(id int) error
+
+	// DeleteAllFaces deletes all faces in the store.
+	DeleteAllFaces() error
+
+	// GetAllFaces returns all the faces in the store, in arbitrary order.
+	GetAllFaces() ([]Face, error)
+
+	// GetFacesByTag returns all the faces that have the given tag, in
+	// arbitrary order.
+	GetFacesByTag(tag string) ([]Face, error)
+
+	// GetFacesByDueDate returns all the faces that have the given due date,
+	// in arbitrary order.
+	GetFacesByDueDate(year int, month time.Month, day int) ([]Face, error)
+
+	// Watch streams Events for every mutation after fromRev (0 meaning "from
+	// the current revision"). The returned channel is closed when ctx is
+	// done. Implementations that can no longer produce events starting at
+	// fromRev (the history has been compacted) return ErrCompacted.
+	Watch(ctx context.Context, fromRev int64) (<-chan Event, error)
+}
+
+// FacePatch carries the fields that UpdateFace should change; nil fields are
+// left untouched. This lets a single UpdateFace method back both PUT (every
+// field set) and PATCH (only the fields the client sent).
+type FacePatch struct {
+	Text *string
+	Tags *[]string
+	Due  *time.Time
+
+	// IfMatchVersion, when set, makes UpdateFace fail with
+	// ErrPreconditionFailed instead of applying the patch if the face's
+	// current Version doesn't match. This backs the REST layer's If-Match
+	// support so concurrent PATCH clients don't silently clobber each
+	// other.
+	IfMatchVersion *int
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"face/facestore"
+	facepb "face/proto"
+	"time"
+)
+
+// faceGRPCService implements facepb.FaceServiceServer on top of the same
+// facestore.Store the REST handlers use, so the two APIs always see the
+// same data.
+type faceGRPCService struct {
+	store facestore.Store
+}
+
+func newFaceGRPCService(store facestore.Store) *faceGRPCService {
+	return &faceGRPCService{store: store}
+}
+
+func (s *faceGRPCService) CreateFace(ctx context.Context, req *facepb.CreateFaceRequest) (*facepb.CreateFaceResponse, error) {
+	id, err := s.store.CreateFace(req.Text, req.Tags, req.Due)
+	if err != nil {
+		return nil, err
+	}
+	return &facepb.CreateFaceResponse{Id: int64(id)}, nil
+}
+
+func (s *faceGRPCService) GetFace(ctx context.Context, req *facepb.GetFaceRequest) (*facepb.Face, error) {
+	f, err := s.store.GetFace(int(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	return toProtoFace(f), nil
+}
+
+func (s *faceGRPCService) ListFaces(ctx context.Context, req *facepb.ListFacesRequest) (*facepb.ListFacesResponse, error) {
+	faces, err := s.store.GetAllFaces()
+	if err != nil {
+		return nil, err
+	}
+	return &facepb.ListFacesResponse{Faces: toProtoFaces(faces)}, nil
+}
+
+func (s *faceGRPCService) DeleteFace(ctx context.Context, req *facepb.DeleteFaceRequest) (*facepb.DeleteFaceResponse, error) {
+	if err := s.store.DeleteFace(int(req.Id)); err != nil {
+		return nil, err
+	}
+	return &facepb.DeleteFaceResponse{}, nil
+}
+
+func (s *faceGRPCService) GetFacesByTag(ctx context.Context, req *facepb.GetFacesByTagRequest) (*facepb.ListFacesResponse, error) {
+	faces, err := s.store.GetFacesByTag(req.Tag)
+	if err != nil {
+		return nil, err
+	}
+	return &facepb.ListFacesResponse{Faces: toProtoFaces(faces)}, nil
+}
+
+func (s *faceGRPCService) GetFacesByDueDate(ctx context.Context, req *facepb.GetFacesByDueDateRequest) (*facepb.GetFacesByDueDateResponse, error) {
+	faces, err := s.store.GetFacesByDueDate(int(req.Year), time.Month(req.Month), int(req.Day))
+	if err != nil {
+		return nil, err
+	}
+	return &facepb.GetFacesByDueDateResponse{Faces: toProtoFaces(faces)}, nil
+}
+
+func (s *faceGRPCService) WatchFaces(req *facepb.WatchFacesRequest, stream facepb.FaceService_WatchFacesServer) error {
+	events, err := s.store.Watch(stream.Context(), req.SinceRev)
+	if err != nil {
+		return err
+	}
+	for ev := range events {
+		if err := stream.Send(&facepb.WatchFacesResponse{
+			Rev:  ev.Rev,
+			Type: ev.Type.String(),
+			Face: toProtoFace(ev.Face),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toProtoFace(f facestore.Face) *facepb.Face {
+	return &facepb.Face{Id: int64(f.Id), Text: f.Text, Tags: f.Tags, Due: f.Due}
+}
+
+func toProtoFaces(faces []facestore.Face) []*facepb.Face {
+	out := make([]*facepb.Face, len(faces))
+	for i, f := range faces {
+		out[i] = toProtoFace(f)
+	}
+	return out
+}
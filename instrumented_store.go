@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"face/facestore"
+	"time"
+)
+
+// instrumentedStore decorates a facestore.Store, recording
+// face_store_operations_total on every call and refreshing face_store_size
+// after every create or delete, so any backend (mem, sqlite, etcd) reports the same metrics without
+// having to know about Prometheus itself.
+type instrumentedStore struct {
+	facestore.Store
+}
+
+func newInstrumentedStore(s facestore.Store) facestore.Store {
+	return &instrumentedStore{Store: s}
+}
+
+// refreshSize re-reads the store's size after a mutation with a full
+// GetAllFaces, which is O(n) in every backend.
+func (s *instrumentedStore) refreshSize() {
+	if faces, err := s.Store.GetAllFaces(); err == nil {
+		storeSize.Set(float64(len(faces)))
+	}
+}
+
+func (s *instrumentedStore) CreateFace(text string, tags []string, due time.Time) (int, error) {
+	storeOperationsTotal.WithLabelValues("create").Inc()
+	id, err := s.Store.CreateFace(text, tags, due)
+	s.refreshSize()
+	return id, err
+}
+
+func (s *instrumentedStore) GetFace(id int) (facestore.Face, error) {
+	storeOperationsTotal.WithLabelValues("get").Inc()
+	return s.Store.GetFace(id)
+}
+
+func (s *instrumentedStore) UpdateFace(id int, patch facestore.FacePatch) (facestore.Face, error) {
+	storeOperationsTotal.WithLabelValues("update").Inc()
+	return s.Store.UpdateFace(id, patch)
+}
+
+func (s *instrumentedStore) DeleteFace(id int) error {
+	storeOperationsTotal.WithLabelValues("delete").Inc()
+	err := s.Store.DeleteFace(id)
+	s.refreshSize()
+	return err
+}
+
+func (s *instrumentedStore) DeleteAllFaces() error {
+	storeOperationsTotal.WithLabelValues("delete_all").Inc()
+	err := s.Store.DeleteAllFaces()
+	s.refreshSize()
+	return err
+}
+
+func (s *instrumentedStore) GetAllFaces() ([]facestore.Face, error) {
+	storeOperationsTotal.WithLabelValues("get_all").Inc()
+	return s.Store.GetAllFaces()
+}
+
+func (s *instrumentedStore) GetFacesByTag(tag string) ([]facestore.Face, error) {
+	storeOperationsTotal.WithLabelValues("get_by_tag").Inc()
+	return s.Store.GetFacesByTag(tag)
+}
+
+func (s *instrumentedStore) GetFacesByDueDate(year int, month time.Month, day int) ([]facestore.Face, error) {
+	storeOperationsTotal.WithLabelValues("get_by_due").Inc()
+	return s.Store.GetFacesByDueDate(year, month, day)
+}
+
+func (s *instrumentedStore) Watch(ctx context.Context, fromRev int64) (<-chan facestore.Event, error) {
+	storeOperationsTotal.WithLabelValues("watch").Inc()
+	return s.Store.Watch(ctx, fromRev)
+}
+
+var _ facestore.Store = (*instrumentedStore)(nil)
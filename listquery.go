@@ -0,0 +1,123 @@
+package main
+
+import (
+	"face/facestore"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// faceList is the shape every list endpoint (GetAllFaces, /tag/, /due/)
+// responds with once filtering and pagination are applied.
+type faceList struct {
+	Items      []facestore.Face `json:"items"`
+	NextOffset *int             `json:"next_offset,omitempty"`
+	Total      int              `json:"total"`
+}
+
+// filterAndPaginate narrows faces down using the ?tag=, ?due_before=,
+// ?due_after=, and ?text_contains= query parameters, sorts the result per
+// ?sort= (due, -due, id or -id; id ascending is the default), and slices
+// out a page per ?limit=/?offset=. due_before/due_after are parsed as
+// RFC 3339.
+func filterAndPaginate(faces []facestore.Face, q url.Values) (faceList, error) {
+	filtered := make([]facestore.Face, 0, len(faces))
+
+	tag := q.Get("tag")
+	textContains := q.Get("text_contains")
+
+	var dueBefore, dueAfter time.Time
+	if v := q.Get("due_before"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return faceList{}, err
+		}
+		dueBefore = t
+	}
+	if v := q.Get("due_after"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return faceList{}, err
+		}
+		dueAfter = t
+	}
+
+faceloop:
+	for _, f := range faces {
+		if tag != "" {
+			found := false
+			for _, t := range f.Tags {
+				if t == tag {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue faceloop
+			}
+		}
+		if textContains != "" && !strings.Contains(f.Text, textContains) {
+			continue faceloop
+		}
+		if !dueBefore.IsZero() && !f.Due.Before(dueBefore) {
+			continue faceloop
+		}
+		if !dueAfter.IsZero() && !f.Due.After(dueAfter) {
+			continue faceloop
+		}
+		filtered = append(filtered, f)
+	}
+
+	switch q.Get("sort") {
+	case "due":
+		sort.Slice(filtered, func(i, j int) bool { return filtered[i].Due.Before(filtered[j].Due) })
+	case "-due":
+		sort.Slice(filtered, func(i, j int) bool { return filtered[i].Due.After(filtered[j].Due) })
+	case "-id":
+		sort.Slice(filtered, func(i, j int) bool { return filtered[i].Id > filtered[j].Id })
+	default:
+		sort.Slice(filtered, func(i, j int) bool { return filtered[i].Id < filtered[j].Id })
+	}
+
+	total := len(filtered)
+
+	offset := 0
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return faceList{}, err
+		}
+		if n < 0 {
+			return faceList{}, fmt.Errorf("offset must not be negative, got %d", n)
+		}
+		offset = n
+	}
+	if offset > total {
+		offset = total
+	}
+
+	end := total
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return faceList{}, err
+		}
+		if n < 0 {
+			return faceList{}, fmt.Errorf("limit must not be negative, got %d", n)
+		}
+		if offset+n < end {
+			end = offset + n
+		}
+	}
+
+	page := filtered[offset:end]
+	result := faceList{Items: page, Total: total}
+	if end < total {
+		next := end
+		result.NextOffset = &next
+	}
+	return result, nil
+}
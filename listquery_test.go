@@ -0,0 +1,62 @@
+package main
+
+import (
+	"face/facestore"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func mkFaces(n int) []facestore.Face {
+	faces := make([]facestore.Face, n)
+	for i := range faces {
+		faces[i] = facestore.Face{
+			Id:   i,
+			Text: "face",
+			Due:  time.Date(2026, 1, 1+i, 0, 0, 0, 0, time.UTC),
+		}
+	}
+	return faces
+}
+
+func TestFilterAndPaginateNegativeOffset(t *testing.T) {
+	q := url.Values{"offset": {"-1"}}
+	if _, err := filterAndPaginate(mkFaces(3), q); err == nil {
+		t.Fatal("expected an error for negative offset, got nil")
+	}
+}
+
+func TestFilterAndPaginateNegativeLimit(t *testing.T) {
+	q := url.Values{"limit": {"-1"}}
+	if _, err := filterAndPaginate(mkFaces(3), q); err == nil {
+		t.Fatal("expected an error for negative limit, got nil")
+	}
+}
+
+func TestFilterAndPaginatePage(t *testing.T) {
+	q := url.Values{"offset": {"1"}, "limit": {"1"}}
+	list, err := filterAndPaginate(mkFaces(3), q)
+	if err != nil {
+		t.Fatalf("filterAndPaginate: %v", err)
+	}
+	if list.Total != 3 {
+		t.Fatalf("Total = %d, want 3", list.Total)
+	}
+	if len(list.Items) != 1 || list.Items[0].Id != 1 {
+		t.Fatalf("Items = %+v, want a single face with id 1", list.Items)
+	}
+	if list.NextOffset == nil || *list.NextOffset != 2 {
+		t.Fatalf("NextOffset = %v, want 2", list.NextOffset)
+	}
+}
+
+func TestFilterAndPaginateOffsetBeyondTotal(t *testing.T) {
+	q := url.Values{"offset": {"10"}}
+	list, err := filterAndPaginate(mkFaces(3), q)
+	if err != nil {
+		t.Fatalf("filterAndPaginate: %v", err)
+	}
+	if len(list.Items) != 0 {
+		t.Fatalf("Items = %+v, want none", list.Items)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "face_http_requests_total",
+		Help: "Total HTTP requests handled by the face API, by route/method/status.",
+	}, []string{"route", "method", "code"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "face_http_request_duration_seconds",
+		Help: "Latency of face API HTTP requests, by route/method.",
+	}, []string{"route", "method"})
+
+	storeOperationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "face_store_operations_total",
+		Help: "Total FaceStore operations performed, by op.",
+	}, []string{"op"})
+
+	storeSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "face_store_size",
+		Help: "Current number of faces held by the store.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(httpRequestsTotal, httpRequestDuration, storeOperationsTotal, storeSize)
+}
+
+// instrument wraps next with Prometheus counters/histograms labeled by
+// route: a caller-supplied, low-cardinality name for the endpoint (e.g.
+// "face"), never the raw URL path, which would mint a new series per face
+// id.
+func instrument(route string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		next(rec, req)
+		httpRequestsTotal.WithLabelValues(route, req.Method, strconv.Itoa(rec.code)).Inc()
+		httpRequestDuration.WithLabelValues(route, req.Method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// statusRecorder captures the status code a handler writes, since
+// http.ResponseWriter doesn't expose it after the fact.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Flush forwards to the wrapped ResponseWriter, so streaming handlers such
+// as watchSSE still see an http.Flusher behind instrument.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
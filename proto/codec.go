@@ -0,0 +1,37 @@
+package facepb
+
+import (
+	"encoding/json"
+)
+
+// Codec returns the encoding.Codec FaceService's gRPC server and clients use
+// to marshal requests. facepb's message types are plain Go structs rather
+// than protobuf-go generated ones with descriptors, so they can't go
+// through the real protobuf wire codec; callers must opt into this codec
+// explicitly (grpc.ForceServerCodec on the server, grpc.ForceCodec on the
+// client) rather than it being registered as a process-wide default, since
+// a process may also hold other gRPC connections (e.g. the etcd client)
+// that do expect real protobuf on the wire.
+//
+// Because of that, FaceService only speaks gRPC to clients that also force
+// this codec, like facectl -- it does not interoperate with grpcurl or any
+// other standard gRPC client/stub, which all expect the real protobuf wire
+// format. It rides gRPC's framing (HTTP/2, streaming, status codes) but is
+// otherwise a custom RPC mechanism, not a standards-compliant gRPC service.
+func Codec() jsonCodec {
+	return jsonCodec{}
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return "facepb-json"
+}
@@ -0,0 +1,252 @@
+package facepb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// faceServiceName is the gRPC service's full name, used both to register it
+// on the server and to build each method's full path on the client.
+const faceServiceName = "face.v1.FaceService"
+
+// FaceServiceServer is the server API for FaceService. It is implemented by
+// faceGRPCService (grpcserver.go), which backs every method with the same
+// facestore.Store the REST handlers in server.go use.
+type FaceServiceServer interface {
+	CreateFace(context.Context, *CreateFaceRequest) (*CreateFaceResponse, error)
+	GetFace(context.Context, *GetFaceRequest) (*Face, error)
+	ListFaces(context.Context, *ListFacesRequest) (*ListFacesResponse, error)
+	DeleteFace(context.Context, *DeleteFaceRequest) (*DeleteFaceResponse, error)
+	GetFacesByTag(context.Context, *GetFacesByTagRequest) (*ListFacesResponse, error)
+	GetFacesByDueDate(context.Context, *GetFacesByDueDateRequest) (*GetFacesByDueDateResponse, error)
+	WatchFaces(*WatchFacesRequest, FaceService_WatchFacesServer) error
+}
+
+// FaceService_WatchFacesServer is the server-side stream for WatchFaces.
+type FaceService_WatchFacesServer interface {
+	Send(*WatchFacesResponse) error
+	grpc.ServerStream
+}
+
+type faceServiceWatchFacesServer struct {
+	grpc.ServerStream
+}
+
+func (s *faceServiceWatchFacesServer) Send(resp *WatchFacesResponse) error {
+	return s.ServerStream.SendMsg(resp)
+}
+
+// RegisterFaceServiceServer registers srv's methods on s.
+func RegisterFaceServiceServer(s *grpc.Server, srv FaceServiceServer) {
+	s.RegisterService(&faceServiceDesc, srv)
+}
+
+var faceServiceDesc = grpc.ServiceDesc{
+	ServiceName: faceServiceName,
+	HandlerType: (*FaceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "CreateFace", Handler: faceServiceCreateFaceHandler},
+		{MethodName: "GetFace", Handler: faceServiceGetFaceHandler},
+		{MethodName: "ListFaces", Handler: faceServiceListFacesHandler},
+		{MethodName: "DeleteFace", Handler: faceServiceDeleteFaceHandler},
+		{MethodName: "GetFacesByTag", Handler: faceServiceGetFacesByTagHandler},
+		{MethodName: "GetFacesByDueDate", Handler: faceServiceGetFacesByDueDateHandler},
+	},
+	Streams: []grpc.StreamDesc{
+		{StreamName: "WatchFaces", Handler: faceServiceWatchFacesHandler, ServerStreams: true},
+	},
+	Metadata: "face.proto",
+}
+
+func faceServiceCreateFaceHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaceServiceServer).CreateFace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + faceServiceName + "/CreateFace"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaceServiceServer).CreateFace(ctx, req.(*CreateFaceRequest))
+	})
+}
+
+func faceServiceGetFaceHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaceServiceServer).GetFace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + faceServiceName + "/GetFace"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaceServiceServer).GetFace(ctx, req.(*GetFaceRequest))
+	})
+}
+
+func faceServiceListFacesHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaceServiceServer).ListFaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + faceServiceName + "/ListFaces"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaceServiceServer).ListFaces(ctx, req.(*ListFacesRequest))
+	})
+}
+
+func faceServiceDeleteFaceHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaceServiceServer).DeleteFace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + faceServiceName + "/DeleteFace"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaceServiceServer).DeleteFace(ctx, req.(*DeleteFaceRequest))
+	})
+}
+
+func faceServiceGetFacesByTagHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFacesByTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaceServiceServer).GetFacesByTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + faceServiceName + "/GetFacesByTag"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaceServiceServer).GetFacesByTag(ctx, req.(*GetFacesByTagRequest))
+	})
+}
+
+func faceServiceGetFacesByDueDateHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFacesByDueDateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaceServiceServer).GetFacesByDueDate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + faceServiceName + "/GetFacesByDueDate"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaceServiceServer).GetFacesByDueDate(ctx, req.(*GetFacesByDueDateRequest))
+	})
+}
+
+func faceServiceWatchFacesHandler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchFacesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FaceServiceServer).WatchFaces(m, &faceServiceWatchFacesServer{stream})
+}
+
+// FaceServiceClient is the client API for FaceService.
+type FaceServiceClient interface {
+	CreateFace(ctx context.Context, in *CreateFaceRequest, opts ...grpc.CallOption) (*CreateFaceResponse, error)
+	GetFace(ctx context.Context, in *GetFaceRequest, opts ...grpc.CallOption) (*Face, error)
+	ListFaces(ctx context.Context, in *ListFacesRequest, opts ...grpc.CallOption) (*ListFacesResponse, error)
+	DeleteFace(ctx context.Context, in *DeleteFaceRequest, opts ...grpc.CallOption) (*DeleteFaceResponse, error)
+	GetFacesByTag(ctx context.Context, in *GetFacesByTagRequest, opts ...grpc.CallOption) (*ListFacesResponse, error)
+	GetFacesByDueDate(ctx context.Context, in *GetFacesByDueDateRequest, opts ...grpc.CallOption) (*GetFacesByDueDateResponse, error)
+	WatchFaces(ctx context.Context, in *WatchFacesRequest, opts ...grpc.CallOption) (FaceService_WatchFacesClient, error)
+}
+
+type faceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFaceServiceClient(cc grpc.ClientConnInterface) FaceServiceClient {
+	return &faceServiceClient{cc}
+}
+
+func (c *faceServiceClient) CreateFace(ctx context.Context, in *CreateFaceRequest, opts ...grpc.CallOption) (*CreateFaceResponse, error) {
+	out := new(CreateFaceResponse)
+	if err := c.cc.Invoke(ctx, "/"+faceServiceName+"/CreateFace", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faceServiceClient) GetFace(ctx context.Context, in *GetFaceRequest, opts ...grpc.CallOption) (*Face, error) {
+	out := new(Face)
+	if err := c.cc.Invoke(ctx, "/"+faceServiceName+"/GetFace", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faceServiceClient) ListFaces(ctx context.Context, in *ListFacesRequest, opts ...grpc.CallOption) (*ListFacesResponse, error) {
+	out := new(ListFacesResponse)
+	if err := c.cc.Invoke(ctx, "/"+faceServiceName+"/ListFaces", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faceServiceClient) DeleteFace(ctx context.Context, in *DeleteFaceRequest, opts ...grpc.CallOption) (*DeleteFaceResponse, error) {
+	out := new(DeleteFaceResponse)
+	if err := c.cc.Invoke(ctx, "/"+faceServiceName+"/DeleteFace", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faceServiceClient) GetFacesByTag(ctx context.Context, in *GetFacesByTagRequest, opts ...grpc.CallOption) (*ListFacesResponse, error) {
+	out := new(ListFacesResponse)
+	if err := c.cc.Invoke(ctx, "/"+faceServiceName+"/GetFacesByTag", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faceServiceClient) GetFacesByDueDate(ctx context.Context, in *GetFacesByDueDateRequest, opts ...grpc.CallOption) (*GetFacesByDueDateResponse, error) {
+	out := new(GetFacesByDueDateResponse)
+	if err := c.cc.Invoke(ctx, "/"+faceServiceName+"/GetFacesByDueDate", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faceServiceClient) WatchFaces(ctx context.Context, in *WatchFacesRequest, opts ...grpc.CallOption) (FaceService_WatchFacesClient, error) {
+	stream, err := c.cc.(*grpc.ClientConn).NewStream(ctx, &faceServiceDesc.Streams[0], "/"+faceServiceName+"/WatchFaces", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &faceServiceWatchFacesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// FaceService_WatchFacesClient is the client-side stream for WatchFaces.
+type FaceService_WatchFacesClient interface {
+	Recv() (*WatchFacesResponse, error)
+	grpc.ClientStream
+}
+
+type faceServiceWatchFacesClient struct {
+	grpc.ClientStream
+}
+
+func (x *faceServiceWatchFacesClient) Recv() (*WatchFacesResponse, error) {
+	m := new(WatchFacesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
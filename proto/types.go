@@ -0,0 +1,69 @@
+// package facepb holds hand-written Go types for face.proto's messages --
+// these are NOT protoc output (no Reset/ProtoReflect/descriptors, so they
+// don't implement proto.Message), just plain structs that mirror the
+// message definitions field-for-field closely enough for codec.go's JSON
+// codec to marshal them over gRPC. One deliberate divergence from the
+// schema: face.proto's "due" fields are google.protobuf.Timestamp, but
+// these use time.Time directly, since there's no protoc toolchain in this
+// repo to generate the real timestamppb type. See service.go for the
+// service interfaces built on top of these types.
+package facepb
+
+import "time"
+
+type Face struct {
+	Id   int64     `json:"id"`
+	Text string    `json:"text"`
+	Tags []string  `json:"tags"`
+	Due  time.Time `json:"due"`
+}
+
+type CreateFaceRequest struct {
+	Text string    `json:"text"`
+	Tags []string  `json:"tags"`
+	Due  time.Time `json:"due"`
+}
+
+type CreateFaceResponse struct {
+	Id int64 `json:"id"`
+}
+
+type GetFaceRequest struct {
+	Id int64 `json:"id"`
+}
+
+type ListFacesRequest struct{}
+
+type ListFacesResponse struct {
+	Faces []*Face `json:"faces"`
+}
+
+type DeleteFaceRequest struct {
+	Id int64 `json:"id"`
+}
+
+type DeleteFaceResponse struct{}
+
+type GetFacesByTagRequest struct {
+	Tag string `json:"tag"`
+}
+
+type GetFacesByDueDateRequest struct {
+	Year  int32 `json:"year"`
+	Month int32 `json:"month"`
+	Day   int32 `json:"day"`
+}
+
+type GetFacesByDueDateResponse struct {
+	Faces []*Face `json:"faces"`
+}
+
+type WatchFacesRequest struct {
+	SinceRev int64 `json:"since_rev"`
+}
+
+type WatchFacesResponse struct {
+	Rev  int64  `json:"rev"`
+	Type string `json:"type"`
+	Face *Face  `json:"face"`
+}
@@ -0,0 +1,20 @@
+package main
+
+import "net/http"
+
+// route pairs a URL pattern with the low-cardinality label its metrics are
+// recorded under, since the pattern (e.g. "/face/") is what the route
+// represents even though the matched path may carry a trailing id.
+type route struct {
+	pattern string
+	label   string
+	handler http.HandlerFunc
+}
+
+// registerRoutes adds every route to mux, wrapping each handler with the
+// metrics middleware so all requests are instrumented consistently.
+func registerRoutes(mux *http.ServeMux, routes []route) {
+	for _, r := range routes {
+		mux.HandleFunc(r.pattern, instrument(r.label, r.handler))
+	}
+}
@@ -8,22 +8,33 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"face/admin"
 	"face/facestore"
+	facepb "face/proto"
+	"flag"
 	"fmt"
 	"log"
 	"mime"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
 	"strconv"
 	"strings"
 	"time"
+
+	"google.golang.org/grpc"
 )
 
 type faceServer struct {
-	store *facestore.FaceStore
+	store facestore.Store
 }
 
-func NewFaceServer() *faceServer {
-	store := facestore.New()
+// NewFaceServer wraps store, the backend selected by main, in a faceServer.
+// Any facestore.Store implementation works: MemStore, SQLStore, EtcdStore,
+// or a future one.
+func NewFaceServer(store facestore.Store) *faceServer {
 	return &faceServer{store: store}
 }
 
@@ -69,57 +80,162 @@ func (fs *faceServer) faceHandler(w http.ResponseWriter, req *http.Request) {
 			fs.deleteFaceHandler(w, req, id)
 		} else if req.Method == http.MethodGet {
 			fs.getFaceHandler(w, req, id)
+		} else if req.Method == http.MethodPut {
+			fs.putFaceHandler(w, req, id)
+		} else if req.Method == http.MethodPatch {
+			fs.patchFaceHandler(w, req, id)
 		} else {
-			http.Error(w, fmt.Sprintf("expect method GET or DELETE at /face/<id>, got %v", req.Method), http.StatusMethodNotAllowed)
+			http.Error(w, fmt.Sprintf("expect method GET, PUT, PATCH or DELETE at /face/<id>, got %v", req.Method), http.StatusMethodNotAllowed)
 			return
 		}
 	}
 }
 
+// requireJSONContentType enforces a JSON Content-Type, writing the
+// appropriate error response and returning false if the request doesn't
+// have one.
+func requireJSONContentType(w http.ResponseWriter, req *http.Request) bool {
+	contentType := req.Header.Get("Content-Type")
+	mediatype, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	if mediatype != "application/json" {
+		http.Error(w, "expect application/json Content-Type", http.StatusUnsupportedMediaType)
+		return false
+	}
+	return true
+}
+
+// requestFace is the (de-)serialization shape for a full face: used to
+// decode POST /face/ and PUT /face/<id> bodies.
+type requestFace struct {
+	Text string    `json:"text"`
+	Tags []string  `json:"tags"`
+	Due  time.Time `json:"due"`
+}
+
+// requestFacePatch is the (de-)serialization shape for a partial face:
+// used to decode PATCH /face/<id> bodies. A nil field means "leave
+// unchanged".
+type requestFacePatch struct {
+	Text *string    `json:"text,omitempty"`
+	Tags *[]string  `json:"tags,omitempty"`
+	Due  *time.Time `json:"due,omitempty"`
+}
+
 func (fs *faceServer) createFaceHandler(w http.ResponseWriter, req *http.Request) {
 	log.Printf("handling task create at %s\n", req.URL.Path)
 
-	// Types used internally in this handler to (de-)serialize the request and
-	// response from/to JSON.
-	type RequestFace struct {
-		Text string    `json:"text"`
-		Tags []string  `json:"tags"`
-		Due  time.Time `json:"due"`
-	}
-
 	type ResponseId struct {
 		Id int `json:"id"`
 	}
 
-	// Enforce a JSON Content-Type.
-	contentType := req.Header.Get("Content-Type")
-	mediatype, _, err := mime.ParseMediaType(contentType)
+	if !requireJSONContentType(w, req) {
+		return
+	}
+
+	dec := json.NewDecoder(req.Body)
+	dec.DisallowUnknownFields()
+	var rf requestFace
+	if err := dec.Decode(&rf); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id, err := fs.store.CreateFace(rf.Text, rf.Tags, rf.Due)
 	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	renderJSON(w, ResponseId{Id: id})
+}
+
+// putFaceHandler handles PUT /face/<id>: a full replace of text, tags and
+// due.
+func (fs *faceServer) putFaceHandler(w http.ResponseWriter, req *http.Request, id int) {
+	log.Printf("handling face replace at %s\n", req.URL.Path)
+
+	if !requireJSONContentType(w, req) {
+		return
+	}
+
+	dec := json.NewDecoder(req.Body)
+	dec.DisallowUnknownFields()
+	var rf requestFace
+	if err := dec.Decode(&rf); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	if mediatype != "application/json" {
-		http.Error(w, "expect application/json Content-Type", http.StatusUnsupportedMediaType)
+
+	fs.updateFace(w, req, id, facestore.FacePatch{Text: &rf.Text, Tags: &rf.Tags, Due: &rf.Due})
+}
+
+// patchFaceHandler handles PATCH /face/<id>: a partial update of whichever
+// of text, tags and due the client sent.
+func (fs *faceServer) patchFaceHandler(w http.ResponseWriter, req *http.Request, id int) {
+	log.Printf("handling face patch at %s\n", req.URL.Path)
+
+	if !requireJSONContentType(w, req) {
 		return
 	}
 
 	dec := json.NewDecoder(req.Body)
 	dec.DisallowUnknownFields()
-	var rf RequestFace
+	var rf requestFacePatch
 	if err := dec.Decode(&rf); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	id := fs.store.CreateFace(rf.Text, rf.Tags, rf.Due)
-	renderJSON(w, ResponseId{Id: id})
+	fs.updateFace(w, req, id, facestore.FacePatch{Text: rf.Text, Tags: rf.Tags, Due: rf.Due})
+}
+
+// updateFace applies patch to the face with the given id, honoring an
+// If-Match request header as an optimistic-concurrency precondition, and
+// renders the updated face with its new ETag.
+func (fs *faceServer) updateFace(w http.ResponseWriter, req *http.Request, id int, patch facestore.FacePatch) {
+	if ifMatch := req.Header.Get("If-Match"); ifMatch != "" {
+		v, err := strconv.Atoi(strings.Trim(ifMatch, `"`))
+		if err != nil {
+			http.Error(w, "malformed If-Match", http.StatusBadRequest)
+			return
+		}
+		patch.IfMatchVersion = &v
+	}
+
+	f, err := fs.store.UpdateFace(id, patch)
+	if err != nil {
+		switch {
+		case errors.Is(err, facestore.ErrPreconditionFailed):
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		case errors.Is(err, facestore.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	setETag(w, f)
+	renderJSON(w, f)
+}
+
+// setETag renders f's Version as a strong entity tag, quoted per RFC 9110.
+func setETag(w http.ResponseWriter, f facestore.Face) {
+	w.Header().Set("ETag", fmt.Sprintf(`"%d"`, f.Version))
 }
 
 func (fs *faceServer) getAllFacesHandler(w http.ResponseWriter, req *http.Request) {
 	log.Printf("handling get all tasks at %s\n", req.URL.Path)
 
-	allTasks := fs.store.GetAllFaces()
-	renderJSON(w, allTasks)
+	allTasks, err := fs.store.GetAllFaces()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	renderFaceList(w, allTasks, req.URL.Query())
 }
 
 func (fs *faceServer) getFaceHandler(w http.ResponseWriter, req *http.Request, id int) {
@@ -131,9 +247,22 @@ func (fs *faceServer) getFaceHandler(w http.ResponseWriter, req *http.Request, i
 		return
 	}
 
+	setETag(w, task)
 	renderJSON(w, task)
 }
 
+// renderFaceList applies the list endpoints' shared ?tag=/?due_before=/
+// ?due_after=/?text_contains=/?limit=/?offset=/?sort= query parameters to
+// faces and renders the resulting page.
+func renderFaceList(w http.ResponseWriter, faces []facestore.Face, q url.Values) {
+	list, err := filterAndPaginate(faces, q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	renderJSON(w, list)
+}
+
 func (fs *faceServer) deleteFaceHandler(w http.ResponseWriter, req *http.Request, id int) {
 	log.Printf("handling delete task at %s\n", req.URL.Path)
 
@@ -164,8 +293,12 @@ func (fs *faceServer) tagHandler(w http.ResponseWriter, req *http.Request) {
 	}
 	tag := pathParts[1]
 
-	tasks := fs.store.GetFacesByTag(tag)
-	renderJSON(w, tasks)
+	tasks, err := fs.store.GetFacesByTag(tag)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	renderFaceList(w, tasks, req.URL.Query())
 }
 
 func (fs *faceServer) dueHandler(w http.ResponseWriter, req *http.Request) {
@@ -203,16 +336,74 @@ func (fs *faceServer) dueHandler(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	tasks := fs.store.GetFacesByDueDate(year, time.Month(month), day)
-	renderJSON(w, tasks)
+	tasks, err := fs.store.GetFacesByDueDate(year, time.Month(month), day)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	renderFaceList(w, tasks, req.URL.Query())
+}
+
+// newStore builds the facestore.Store backend named by kind: "mem" (the
+// default), "sqlite" (dsn is the database file path), or "etcd" (dsn is a
+// comma-separated list of endpoints).
+func newStore(kind, dsn string) (facestore.Store, error) {
+	switch kind {
+	case "", "mem":
+		return facestore.NewMemStore(), nil
+	case "sqlite":
+		return facestore.NewSQLStore(dsn)
+	case "etcd":
+		return facestore.NewEtcdStore(strings.Split(dsn, ","))
+	default:
+		return nil, fmt.Errorf("unknown -store backend %q, want mem, sqlite or etcd", kind)
+	}
 }
 
 func main() {
+	storeKind := flag.String("store", envOrDefault("FACE_STORE", "mem"), "store backend: mem, sqlite or etcd")
+	storeDSN := flag.String("store-dsn", envOrDefault("FACE_STORE_DSN", "faces.db"), "backend-specific connection string (sqlite file path, etcd endpoints)")
+	adminAddr := flag.String("admin-addr", envOrDefault("FACE_ADMIN_ADDR", "localhost:8081"), "address for the /metrics, /healthz, /readyz and /admin/faces endpoints")
+	grpcAddr := flag.String("grpc-addr", envOrDefault("FACE_GRPC_ADDR", "localhost:9090"), "address for the gRPC FaceService listener")
+	flag.Parse()
+
+	store, err := newStore(*storeKind, *storeDSN)
+	if err != nil {
+		log.Fatal(err)
+	}
+	store = newInstrumentedStore(store)
+
 	mux := http.NewServeMux()
-	server := NewFaceServer()
-	mux.HandleFunc("/face/", server.faceHandler)
-	mux.HandleFunc("/tag/", server.tagHandler)
-	mux.HandleFunc("/due/", server.dueHandler)
+	server := NewFaceServer(store)
+	registerRoutes(mux, []route{
+		{"/face/", "face", server.faceHandler},
+		{"/tag/", "tag", server.tagHandler},
+		{"/due/", "due", server.dueHandler},
+		{"/watch/", "watch", server.watchHandler},
+	})
+
+	go func() {
+		log.Printf("admin surface listening on %s", *adminAddr)
+		log.Fatal(http.ListenAndServe(*adminAddr, admin.NewMux(store)))
+	}()
+
+	go func() {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		grpcServer := grpc.NewServer(grpc.ForceServerCodec(facepb.Codec()))
+		facepb.RegisterFaceServiceServer(grpcServer, newFaceGRPCService(store))
+		log.Printf("gRPC FaceService listening on %s", *grpcAddr)
+		log.Fatal(grpcServer.Serve(lis))
+	}()
 
 	log.Fatal(http.ListenAndServe("localhost:8080", mux))
 }
+
+func envOrDefault(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"face/facestore"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// watchHandler serves /watch/. It supports two modes selected by the
+// request's Accept header:
+//
+//   - application/json (the default): a single long-poll response that
+//     blocks until the next event after ?since=<rev>, or until ?timeout
+//     elapses, in which case it responds with 204 No Content.
+//   - text/event-stream: a persistent SSE stream that keeps emitting
+//     events with "id: <rev>" lines, so clients can resume with
+//     Last-Event-ID after a reconnect.
+//
+// In both modes, asking for a revision outside the store's retained
+// history gets a 409 revision compacted instead of hanging or silently
+// skipping events.
+func (fs *faceServer) watchHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, fmt.Sprintf("expect method GET at /watch/, got %v", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	since, err := parseSinceRev(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if accept := req.Header.Get("Accept"); strings.Contains(accept, "text/event-stream") {
+		fs.watchSSE(w, req, since)
+		return
+	}
+	fs.watchLongPoll(w, req, since)
+}
+
+// parseSinceRev resolves the starting revision for a watch request: a
+// Last-Event-ID header (used by reconnecting SSE clients) takes priority
+// over the ?since= query parameter.
+func parseSinceRev(req *http.Request) (int64, error) {
+	if id := req.Header.Get("Last-Event-ID"); id != "" {
+		return strconv.ParseInt(id, 10, 64)
+	}
+	if since := req.URL.Query().Get("since"); since != "" {
+		return strconv.ParseInt(since, 10, 64)
+	}
+	return 0, nil
+}
+
+func writeCompacted(w http.ResponseWriter) {
+	http.Error(w, facestore.ErrCompacted.Error(), http.StatusConflict)
+}
+
+// writeLagged reports that the subscriber's channel filled up and was
+// disconnected before we could deliver every event -- also a 409, since
+// like a compacted fromRev it means the client can no longer pick up
+// where it left off and must refetch full state instead of retrying with
+// the same since/Last-Event-ID.
+func writeLagged(w http.ResponseWriter) {
+	http.Error(w, "watch subscriber lagged behind and missed events; refetch and restart the watch", http.StatusConflict)
+}
+
+// watchLongPoll blocks until the next event after since, or until the
+// ?timeout (default 30s) elapses, in which case it responds 204 No Content
+// so the client can immediately re-poll with the same since.
+func (fs *faceServer) watchLongPoll(w http.ResponseWriter, req *http.Request, since int64) {
+	timeout := 30 * time.Second
+	if t := req.URL.Query().Get("timeout"); t != "" {
+		d, err := time.ParseDuration(t)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		timeout = d
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	defer cancel()
+
+	events, err := fs.store.Watch(ctx, since)
+	if err != nil {
+		if errors.Is(err, facestore.ErrCompacted) {
+			writeCompacted(w)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	select {
+	case ev, ok := <-events:
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if ev.Type == facestore.EventLagged {
+			writeLagged(w)
+			return
+		}
+		renderJSON(w, ev)
+	case <-ctx.Done():
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// watchSSE keeps the connection open and emits every event as it happens,
+// tagged with "id: <rev>" so a client that reconnects can resume via
+// Last-Event-ID.
+func (fs *faceServer) watchSSE(w http.ResponseWriter, req *http.Request, since int64) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	events, err := fs.store.Watch(req.Context(), since)
+	if err != nil {
+		if errors.Is(err, facestore.ErrCompacted) {
+			writeCompacted(w)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			js, err := json.Marshal(ev)
+			if err != nil {
+				log.Printf("marshaling watch event: %v", err)
+				continue
+			}
+			fmt.Fprintf(w, "id: %d\n", ev.Rev)
+			fmt.Fprintf(w, "data: %s\n\n", js)
+			flusher.Flush()
+		case <-req.Context().Done():
+			return
+		}
+	}
+}